@@ -0,0 +1,52 @@
+package systemctl
+
+import (
+	"context"
+	"os"
+)
+
+/*
+Backend abstracts the mechanism used to talk to systemd. The exec
+backend shells out to /usr/bin/systemctl, the dbus backend talks to
+systemd directly over its private D-Bus socket. Client.backend picks
+whichever is available; callers normally don't need to know which one
+is in use.
+
+Start, Stop, Restart and Reload wait for the systemd job they queue to
+terminate, or for ctx to be done. A ctx that is done first, a job
+result other than "done" reported over D-Bus, and a unit the exec
+backend sees reach "failed" are returned as one of the Err* sentinel
+errors. A non-zero exit of systemctl itself (exec backend), a failure
+to queue the job or an unrecognized job result (D-Bus backend), and a
+failure to query the unit's state are returned as plain errors that
+wrap no sentinel.
+*/
+type Backend interface {
+	Start(ctx context.Context, name string) error
+	Stop(ctx context.Context, name string) error
+	Restart(ctx context.Context, name string) error
+	Reload(ctx context.Context, name string) error
+	Enable(name string) error
+	Disable(name string) error
+	IsActive(name string) (bool, error)
+	IsEnabled(name string) (bool, error)
+}
+
+/*
+systemdRunningPath is the path systemd itself documents (sd_booted(3))
+for detecting that the running init system is actually systemd.
+*/
+const systemdRunningPath = "/run/systemd/system"
+
+/*
+isRunningSystemd reports whether the host is running under systemd.
+*/
+func isRunningSystemd() bool {
+
+	info, err := os.Stat(systemdRunningPath)
+	if err != nil {
+		return false
+	}
+
+	return info.IsDir()
+}
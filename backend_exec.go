@@ -0,0 +1,162 @@
+package systemctl
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+/*
+execBackend implements Backend by shelling out to /usr/bin/systemctl
+on behalf of client, adding --user/--host as client's scope and host
+require.
+*/
+type execBackend struct {
+	client *Client
+}
+
+func newExecBackendFor(c *Client) *execBackend {
+	return &execBackend{client: c}
+}
+
+func (b *execBackend) run(args ...string) ([]byte, error) {
+	return exec.Command("/usr/bin/systemctl", b.client.args(args...)...).CombinedOutput()
+}
+
+/*
+IsActive reports whether name's ActiveState is "active". Other states
+(activating, deactivating, failed, reloading, ...) are reported as
+inactive without an error; only a failure to query systemd is an
+error.
+*/
+func (b *execBackend) IsActive(name string) (bool, error) {
+
+	state, err := b.client.ShowProperty(name, "ActiveState")
+
+	if err != nil {
+		return false, err
+	}
+
+	return state == "active", nil
+}
+
+/*
+IsEnabled reports whether name's UnitFileState is "enabled" or
+"enabled-runtime". Other states (disabled, static, masked, linked,
+alias, indirect, ...) are reported as not enabled without an error.
+*/
+func (b *execBackend) IsEnabled(name string) (bool, error) {
+
+	state, err := b.client.ShowProperty(name, "UnitFileState")
+
+	if err != nil {
+		return false, err
+	}
+
+	return state == "enabled" || state == "enabled-runtime", nil
+}
+
+func (b *execBackend) Enable(name string) error {
+
+	output, err := b.run("enable", name)
+
+	if err != nil {
+		return fmt.Errorf("%s %s", output, err)
+	}
+
+	return nil
+}
+
+func (b *execBackend) Disable(name string) error {
+
+	output, err := b.run("disable", name)
+
+	if err != nil {
+		return fmt.Errorf("%s %s", output, err)
+	}
+
+	return nil
+}
+
+// wantActiveState maps a start/stop/restart verb to the ActiveState
+// transition runJob should wait for. Reload doesn't change ActiveState,
+// so it's absent and runJob skips the wait.
+var wantActiveState = map[string]string{
+	"start":   "active",
+	"restart": "active",
+	"stop":    "inactive",
+}
+
+/*
+runJob runs `systemctl <verb> <name>` with ctx, then polls ActiveState
+with backoff until the expected transition is observed, the unit
+reports "failed", or ctx is done.
+*/
+func (b *execBackend) runJob(ctx context.Context, verb, name string) error {
+
+	if verb == "start" {
+		if masked, err := b.client.ShowProperty(name, "LoadState"); err == nil && masked == "masked" {
+			return fmt.Errorf("%s: %w", name, ErrUnitMasked)
+		}
+	}
+
+	output, err := exec.CommandContext(ctx, "/usr/bin/systemctl", b.client.args(verb, name)...).CombinedOutput()
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return jobContextError(ctx, name)
+		}
+
+		return fmt.Errorf("%s %s", output, err)
+	}
+
+	want, ok := wantActiveState[verb]
+
+	if !ok {
+		return nil
+	}
+
+	backoff := 50 * time.Millisecond
+
+	for {
+		state, err := b.client.ShowProperty(name, "ActiveState")
+
+		if err != nil {
+			return err
+		}
+
+		if state == want {
+			return nil
+		}
+
+		if state == "failed" {
+			return fmt.Errorf("%s: %w", name, ErrJobFailed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return jobContextError(ctx, name)
+		case <-time.After(backoff):
+			if backoff < time.Second {
+				backoff *= 2
+			}
+		}
+	}
+}
+
+func (b *execBackend) Start(ctx context.Context, name string) error {
+	return b.runJob(ctx, "start", name)
+}
+
+func (b *execBackend) Stop(ctx context.Context, name string) error {
+	return b.runJob(ctx, "stop", name)
+}
+
+func (b *execBackend) Restart(ctx context.Context, name string) error {
+	return b.runJob(ctx, "restart", name)
+}
+
+func (b *execBackend) Reload(ctx context.Context, name string) error {
+	return b.runJob(ctx, "reload", name)
+}
@@ -0,0 +1,293 @@
+package systemctl
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+/*
+Client performs systemctl operations for a given Scope and,
+optionally, a remote Host. The package-level functions (Unit,
+IsActive, StartService, ...) are thin wrappers around a default,
+system-scope, local Client, kept for backward compatibility.
+
+A Client's backend (and, with it, any D-Bus connection it opens) is
+built lazily on first use and cached for the Client's lifetime. Callers
+that create their own Clients with NewClient should Close them once
+done to release that connection.
+*/
+type Client struct {
+	Scope Scope
+	Host  string
+
+	backendOnce sync.Once
+	backendVal  Backend
+}
+
+/*
+defaultClient is the Client backing the package-level functions. It's
+never closed, since it lives for the life of the process.
+*/
+var defaultClient = &Client{Scope: SystemScope}
+
+/*
+NewClient returns a Client operating on the local host in scope.
+*/
+func NewClient(scope Scope) *Client {
+	return &Client{Scope: scope}
+}
+
+/*
+WithHost returns a new Client that targets host, passed to systemctl
+as --host=host (e.g. "user@remote"), so operations run over SSH. It
+does not inherit c's cached backend.
+*/
+func (c *Client) WithHost(host string) *Client {
+	return &Client{Scope: c.Scope, Host: host}
+}
+
+/*
+Close releases resources held by c, such as a cached D-Bus connection.
+It's a no-op if c never opened one.
+*/
+func (c *Client) Close() error {
+
+	if b, ok := c.backendVal.(*dbusBackend); ok {
+		b.conn.Close()
+	}
+
+	return nil
+}
+
+/*
+args prepends the --user/--host flags this Client needs to cmdArgs.
+*/
+func (c *Client) args(cmdArgs ...string) []string {
+
+	args := make([]string, 0, len(cmdArgs)+2)
+
+	if c.Scope == UserScope {
+		args = append(args, "--user")
+	}
+
+	if c.Host != "" {
+		args = append(args, "--host="+c.Host)
+	}
+
+	return append(args, cmdArgs...)
+}
+
+/*
+backend picks the Backend this Client should use: D-Bus when operating
+on the local host and systemd is reachable, exec otherwise. Remote
+hosts always use the exec backend, since systemctl itself handles the
+SSH transport via --host. The chosen Backend (and any connection it
+opens) is built once and reused for every subsequent call on c.
+*/
+func (c *Client) backend() Backend {
+
+	c.backendOnce.Do(func() {
+
+		if c.Host == "" && isRunningSystemd() {
+			if b, err := newDbusBackendFor(c); err == nil {
+				c.backendVal = b
+				return
+			}
+		}
+
+		c.backendVal = newExecBackendFor(c)
+	})
+
+	return c.backendVal
+}
+
+/*
+userUnitConfigDir returns the directory user-scope unit files are
+installed into: $XDG_CONFIG_HOME/systemd/user if XDG_CONFIG_HOME is
+set, otherwise ~/.config/systemd/user. Scope.unitDir and
+unitSearchPaths both go through this so they never disagree about
+where a user-scope unit file lives.
+*/
+func userUnitConfigDir() (string, error) {
+
+	if xdgConfigHome := os.Getenv("XDG_CONFIG_HOME"); xdgConfigHome != "" {
+		return filepath.Join(xdgConfigHome, "systemd/user"), nil
+	}
+
+	home, err := os.UserHomeDir()
+
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %s", err)
+	}
+
+	return filepath.Join(home, ".config/systemd/user"), nil
+}
+
+/*
+unitSearchPaths lists the directories unitExist checks for scope.
+*/
+func unitSearchPaths(scope Scope) []string {
+
+	if scope == SystemScope {
+		return []string{
+			"/usr/lib/systemd/system/",
+			"/etc/systemd/system/",
+			"/usr/local/lib/systemd/system/",
+			"/etc/systemd/user/",
+			"/etc/systemd/system.control/",
+			"/run/systemd/system.control/",
+			"/run/systemd/transient/",
+			"/run/systemd/generator.early/",
+			"/etc/systemd/systemd.attached/",
+			"/run/systemd/system/",
+			"/run/systemd/systemd.attached/",
+			"/run/systemd/generator/",
+			"/lib/systemd/system/",
+			"/run/systemd/generator.late/",
+			"/usr/lib/systemd/user/",
+		}
+	}
+
+	paths := []string{
+		"/usr/lib/systemd/user/",
+		"/usr/local/lib/systemd/user/",
+	}
+
+	if dir, err := userUnitConfigDir(); err == nil {
+		paths = append(paths, dir)
+	}
+
+	if xdgRuntimeDir := os.Getenv("XDG_RUNTIME_DIR"); xdgRuntimeDir != "" {
+		paths = append(paths, filepath.Join(xdgRuntimeDir, "systemd/user"))
+	}
+
+	return paths
+}
+
+/*
+unitExist checks whether the given unit exists in c's scope.
+*/
+func (c *Client) unitExist(name string) (bool, error) {
+
+	for _, unitPath := range unitSearchPaths(c.Scope) {
+
+		if _, err := os.Stat(unitPath); os.IsNotExist(err) {
+			continue
+		}
+
+		files, err := ioutil.ReadDir(unitPath)
+
+		if err != nil {
+			return false, err
+		}
+
+		for _, file := range files {
+			if file.Name() == name {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+/*
+Unit gives back Service for name in c's scope.
+*/
+func (c *Client) Unit(name string) (Service, error) {
+
+	if exist, err := c.unitExist(name); err != nil {
+		return Service{}, err
+	} else if exist != true {
+		return Service{}, fmt.Errorf("%w: %s", ErrUnitNotFound, name)
+	}
+
+	properties, err := c.showProperties(name)
+
+	if err != nil {
+		return Service{}, err
+	}
+
+	s := Service{Name: name, client: c, backend: c.backend()}
+	s.applyProperties(properties)
+
+	return s, nil
+}
+
+func (c *Client) IsActive(name string) (bool, error) {
+	return c.backend().IsActive(name)
+}
+
+func (c *Client) IsEnabled(name string) (bool, error) {
+	return c.backend().IsEnabled(name)
+}
+
+func (c *Client) EnableService(name string) error {
+
+	if exist, err := c.unitExist(name); err != nil {
+		return err
+	} else if exist != true {
+		return fmt.Errorf("%w: %s", ErrUnitNotFound, name)
+	}
+
+	return c.backend().Enable(name)
+}
+
+func (c *Client) DisableService(name string) error {
+
+	if exist, err := c.unitExist(name); err != nil {
+		return err
+	} else if exist != true {
+		return fmt.Errorf("%w: %s", ErrUnitNotFound, name)
+	}
+
+	return c.backend().Disable(name)
+}
+
+func (c *Client) StartService(ctx context.Context, name string) error {
+
+	if exist, err := c.unitExist(name); err != nil {
+		return err
+	} else if exist != true {
+		return fmt.Errorf("%w: %s", ErrUnitNotFound, name)
+	}
+
+	return c.backend().Start(ctx, name)
+}
+
+func (c *Client) StopService(ctx context.Context, name string) error {
+
+	if exist, err := c.unitExist(name); err != nil {
+		return err
+	} else if exist != true {
+		return fmt.Errorf("%w: %s", ErrUnitNotFound, name)
+	}
+
+	return c.backend().Stop(ctx, name)
+}
+
+func (c *Client) RestartService(ctx context.Context, name string) error {
+
+	if exist, err := c.unitExist(name); err != nil {
+		return err
+	} else if exist != true {
+		return fmt.Errorf("%w: %s", ErrUnitNotFound, name)
+	}
+
+	return c.backend().Restart(ctx, name)
+}
+
+func (c *Client) ReloadService(ctx context.Context, name string) error {
+
+	if exist, err := c.unitExist(name); err != nil {
+		return err
+	} else if exist != true {
+		return fmt.Errorf("%w: %s", ErrUnitNotFound, name)
+	}
+
+	return c.backend().Reload(ctx, name)
+}
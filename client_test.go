@@ -0,0 +1,51 @@
+package systemctl
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestUserScopeUnitDirRespectsXDGConfigHome(t *testing.T) {
+
+	t.Setenv("XDG_CONFIG_HOME", "/custom/xdg-config")
+
+	want := filepath.Join("/custom/xdg-config", "systemd/user")
+
+	dir, err := UserScope.unitDir()
+
+	if err != nil {
+		t.Fatalf("UserScope.unitDir() returned error: %s", err)
+	}
+
+	if dir != want {
+		t.Errorf("UserScope.unitDir() = %q, want %q", dir, want)
+	}
+
+	paths := unitSearchPaths(UserScope)
+
+	found := false
+
+	for _, p := range paths {
+		if p == want {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		t.Errorf("unitSearchPaths(UserScope) = %v, want it to contain %q (as returned by unitDir)", paths, want)
+	}
+}
+
+func TestSystemScopeUnitDir(t *testing.T) {
+
+	dir, err := SystemScope.unitDir()
+
+	if err != nil {
+		t.Fatalf("SystemScope.unitDir() returned error: %s", err)
+	}
+
+	if dir != "/etc/systemd/system/" {
+		t.Errorf("SystemScope.unitDir() = %q, want /etc/systemd/system/", dir)
+	}
+}
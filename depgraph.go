@@ -0,0 +1,161 @@
+package systemctl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/*
+DepKind selects which systemd unit dependency directive Dependencies
+reads.
+*/
+type DepKind string
+
+const (
+	DepRequires  DepKind = "Requires"
+	DepWants     DepKind = "Wants"
+	DepAfter     DepKind = "After"
+	DepBefore    DepKind = "Before"
+	DepConflicts DepKind = "Conflicts"
+)
+
+/*
+Dependencies returns the unit names systemd reports for s's kind
+directive, via `systemctl show -p <kind>`.
+*/
+func (s *Service) Dependencies(kind DepKind) ([]string, error) {
+
+	value, err := s.getClient().ShowProperty(s.Name, string(kind))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if value == "" {
+		return nil, nil
+	}
+
+	return strings.Fields(value), nil
+}
+
+/*
+dedupe returns items with duplicates removed, preserving order.
+*/
+func dedupe(items []string) []string {
+
+	seen := make(map[string]bool, len(items))
+	out := make([]string, 0, len(items))
+
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			out = append(out, item)
+		}
+	}
+
+	return out
+}
+
+/*
+DepGraph is a directed graph of unit ordering dependencies (After=,
+Requires=), built by BuildGraph. It's useful for computing a safe
+start/stop order for a set of units.
+*/
+type DepGraph struct {
+	// edges maps a unit to the units that must be started before it.
+	edges map[string][]string
+}
+
+/*
+Order returns the units in g in an order that respects every recorded
+edge (a topological sort), or an error if g has a dependency cycle.
+*/
+func (g *DepGraph) Order() ([]string, error) {
+
+	const (
+		white = iota
+		gray
+		black
+	)
+
+	color := make(map[string]int, len(g.edges))
+	order := make([]string, 0, len(g.edges))
+
+	var visit func(string) error
+
+	visit = func(unit string) error {
+
+		switch color[unit] {
+		case black:
+			return nil
+		case gray:
+			return fmt.Errorf("dependency cycle detected at %s", unit)
+		}
+
+		color[unit] = gray
+
+		for _, dep := range g.edges[unit] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+
+		color[unit] = black
+		order = append(order, unit)
+
+		return nil
+	}
+
+	units := make([]string, 0, len(g.edges))
+
+	for unit := range g.edges {
+		units = append(units, unit)
+	}
+
+	sort.Strings(units)
+
+	for _, unit := range units {
+		if err := visit(unit); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+/*
+BuildGraph reads the After= and Requires= directives of each of units
+via c and returns the resulting DepGraph.
+*/
+func (c *Client) BuildGraph(units []string) (*DepGraph, error) {
+
+	g := &DepGraph{edges: make(map[string][]string, len(units))}
+
+	for _, unit := range units {
+
+		after, err := c.ShowProperty(unit, string(DepAfter))
+
+		if err != nil {
+			return nil, err
+		}
+
+		requires, err := c.ShowProperty(unit, string(DepRequires))
+
+		if err != nil {
+			return nil, err
+		}
+
+		deps := append(strings.Fields(after), strings.Fields(requires)...)
+		g.edges[unit] = dedupe(deps)
+	}
+
+	return g, nil
+}
+
+/*
+BuildGraph runs BuildGraph against the default system-scope client.
+*/
+func BuildGraph(units []string) (*DepGraph, error) {
+	return defaultClient.BuildGraph(units)
+}
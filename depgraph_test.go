@@ -0,0 +1,42 @@
+package systemctl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDepGraphOrder(t *testing.T) {
+
+	t.Run("respects edges", func(t *testing.T) {
+
+		g := &DepGraph{edges: map[string][]string{
+			"c.service": {"b.service"},
+			"b.service": {"a.service"},
+			"a.service": nil,
+		}}
+
+		got, err := g.Order()
+
+		if err != nil {
+			t.Fatalf("Order() returned error: %s", err)
+		}
+
+		want := []string{"a.service", "b.service", "c.service"}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Order() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("detects cycles", func(t *testing.T) {
+
+		g := &DepGraph{edges: map[string][]string{
+			"a.service": {"b.service"},
+			"b.service": {"a.service"},
+		}}
+
+		if _, err := g.Order(); err == nil {
+			t.Error("Order() = nil error, want a cycle error")
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package systemctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors returned by job-waiting operations (Start, Stop,
+// Restart, Reload) and unit lookups, so callers can use errors.Is
+// instead of string-matching error text.
+var (
+	ErrJobFailed     = errors.New("systemd job failed")
+	ErrJobCanceled   = errors.New("systemd job canceled")
+	ErrJobTimeout    = errors.New("systemd job timed out")
+	ErrJobDependency = errors.New("systemd job failed due to a dependency")
+	ErrJobSkipped    = errors.New("systemd job skipped")
+	ErrUnitMasked    = errors.New("unit is masked")
+	ErrUnitNotFound  = errors.New("unit not exist")
+)
+
+/*
+jobResultError maps a systemd job result (as returned by the D-Bus
+StartUnit/StopUnit/... job channel) to nil on success or one of the
+sentinel Err* values on failure. An unrecognized result is returned
+as a plain error. The exec backend doesn't go through it.
+*/
+func jobResultError(name, result string) error {
+
+	switch result {
+	case "done":
+		return nil
+	case "canceled":
+		return fmt.Errorf("%s: %w", name, ErrJobCanceled)
+	case "timeout":
+		return fmt.Errorf("%s: %w", name, ErrJobTimeout)
+	case "failed":
+		return fmt.Errorf("%s: %w", name, ErrJobFailed)
+	case "dependency":
+		return fmt.Errorf("%s: %w", name, ErrJobDependency)
+	case "skipped":
+		return fmt.Errorf("%s: %w", name, ErrJobSkipped)
+	default:
+		return fmt.Errorf("%s: unknown systemd job result %q", name, result)
+	}
+}
+
+/*
+jobContextError maps a context error to ErrJobTimeout or ErrJobCanceled.
+*/
+func jobContextError(ctx context.Context, name string) error {
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("%s: %w", name, ErrJobTimeout)
+	}
+
+	return fmt.Errorf("%s: %w", name, ErrJobCanceled)
+}
@@ -0,0 +1,133 @@
+package systemctl
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+/*
+parseShowOutput parses the key=value output of `systemctl show` into a
+map. Multi-line values (e.g. ExecStart=) are not split further here;
+callers that need the individual directives parse those keys
+themselves.
+*/
+func parseShowOutput(output []byte) map[string]string {
+
+	properties := make(map[string]string)
+
+	for _, line := range strings.Split(string(output), "\n") {
+
+		if line == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+
+		if !found {
+			continue
+		}
+
+		properties[key] = value
+	}
+
+	return properties
+}
+
+/*
+showProperties runs `systemctl show <name>` for c's scope/host and
+returns every property systemd reports for the unit as a key=value map.
+*/
+func (c *Client) showProperties(name string) (map[string]string, error) {
+
+	output, err := exec.Command("/usr/bin/systemctl", c.args("show", name)...).CombinedOutput()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to run systemctl: %s %s", output, err)
+	}
+
+	return parseShowOutput(output), nil
+}
+
+/*
+ShowProperty returns the value of a single systemd property for the
+given unit, as reported by `systemctl show <name> -p <key>`.
+*/
+func (c *Client) ShowProperty(name, key string) (string, error) {
+
+	output, err := exec.Command("/usr/bin/systemctl", c.args("show", name, "-p", key)...).CombinedOutput()
+
+	if err != nil {
+		return "", fmt.Errorf("failed to run systemctl: %s %s", output, err)
+	}
+
+	properties := parseShowOutput(output)
+
+	value, ok := properties[key]
+
+	if !ok {
+		return "", fmt.Errorf("property not found: %s", key)
+	}
+
+	return value, nil
+}
+
+/*
+ShowProperty returns the value of a single systemd property for the
+given unit, using the default system-scope client.
+*/
+func ShowProperty(name, key string) (string, error) {
+	return defaultClient.ShowProperty(name, key)
+}
+
+/*
+applyProperties populates s from a systemctl show property map, as
+produced by showProperties. Unrecognized properties are kept in
+Properties so callers aren't limited to the fields this package
+special-cases.
+*/
+func (s *Service) applyProperties(properties map[string]string) {
+
+	s.LoadState = properties["LoadState"]
+	s.ActiveState = properties["ActiveState"]
+	s.SubState = properties["SubState"]
+	s.UnitFileState = properties["UnitFileState"]
+	s.Description = properties["Description"]
+	s.FragmentPath = properties["FragmentPath"]
+	s.ExecMainStartTimestamp = properties["ExecMainStartTimestamp"]
+
+	if pid, err := strconv.Atoi(properties["MainPID"]); err == nil {
+		s.MainPID = pid
+	}
+
+	if mem, err := strconv.ParseUint(properties["MemoryCurrent"], 10, 64); err == nil {
+		s.MemoryCurrent = mem
+	}
+
+	if tasks, err := strconv.ParseUint(properties["TasksCurrent"], 10, 64); err == nil {
+		s.TasksCurrent = tasks
+	}
+
+	s.Active = s.ActiveState == "active"
+	s.Enabled = s.UnitFileState == "enabled"
+
+	s.Properties = properties
+}
+
+/*
+Refresh re-reads the unit's properties from systemd via `systemctl
+show` and updates s in place.
+*/
+func (s *Service) Refresh() error {
+
+	properties, err := s.getClient().showProperties(s.Name)
+
+	if err != nil {
+		return err
+	}
+
+	s.applyProperties(properties)
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package systemctl
+
+import "testing"
+
+func TestParseShowOutput(t *testing.T) {
+
+	output := []byte("LoadState=loaded\nActiveState=active\nSubState=running\nExecStart= ; ignored\nEmpty=\n")
+
+	got := parseShowOutput(output)
+
+	want := map[string]string{
+		"LoadState":   "loaded",
+		"ActiveState": "active",
+		"SubState":    "running",
+		"ExecStart":   " ; ignored",
+		"Empty":       "",
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("parseShowOutput() returned %d properties, want %d: %v", len(got), len(want), got)
+	}
+
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("parseShowOutput()[%q] = %q, want %q", key, got[key], value)
+		}
+	}
+}
+
+func TestApplyProperties(t *testing.T) {
+
+	s := &Service{Name: "sshd.service"}
+
+	s.applyProperties(map[string]string{
+		"LoadState":     "loaded",
+		"ActiveState":   "active",
+		"SubState":      "running",
+		"UnitFileState": "enabled",
+		"MainPID":       "1234",
+		"MemoryCurrent": "4096",
+		"TasksCurrent":  "3",
+	})
+
+	if !s.Active {
+		t.Error("Active = false, want true for ActiveState=active")
+	}
+
+	if !s.Enabled {
+		t.Error("Enabled = false, want true for UnitFileState=enabled")
+	}
+
+	if s.MainPID != 1234 {
+		t.Errorf("MainPID = %d, want 1234", s.MainPID)
+	}
+
+	if s.MemoryCurrent != 4096 {
+		t.Errorf("MemoryCurrent = %d, want 4096", s.MemoryCurrent)
+	}
+
+	if s.TasksCurrent != 3 {
+		t.Errorf("TasksCurrent = %d, want 3", s.TasksCurrent)
+	}
+}
+
+func TestApplyPropertiesMissingNumeric(t *testing.T) {
+
+	s := &Service{Name: "sshd.service", MainPID: 99}
+
+	s.applyProperties(map[string]string{
+		"ActiveState": "inactive",
+	})
+
+	if s.Active {
+		t.Error("Active = true, want false for ActiveState=inactive")
+	}
+
+	if s.MainPID != 99 {
+		t.Errorf("MainPID = %d, want unchanged 99 when MainPID property is absent", s.MainPID)
+	}
+}
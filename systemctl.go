@@ -13,7 +13,7 @@ example:
 		// Handle active service
 	}
 
-	err = nm.Start()
+	err = nm.Start(context.Background())
 	if err != nil {
 	    // Handle error
 	}
@@ -21,93 +21,70 @@ example:
 */
 package systemctl
 
-import (
-	"fmt"
-	"io/ioutil"
-	"os"
-	"os/exec"
-)
+import "context"
 
 /*
-Service provides a struct to store the unit's properties.
-WILL BE EXPANDED LATER!
+Service provides a struct to store the unit's properties, populated
+from `systemctl show <name>`. Active and Enabled are kept for backward
+compatibility and are derived from ActiveState/UnitFileState.
 */
 type Service struct {
 	Name    string
 	Active  bool
 	Enabled bool
+
+	LoadState              string
+	ActiveState            string
+	SubState               string
+	UnitFileState          string
+	Description            string
+	FragmentPath           string
+	MainPID                int
+	ExecMainStartTimestamp string
+	MemoryCurrent          uint64
+	TasksCurrent           uint64
+
+	// Properties holds every property systemctl show reported, including
+	// ones this struct doesn't surface as a dedicated field.
+	Properties map[string]string
+
+	client  *Client
+	backend Backend
 }
 
 /*
-unitExist check whether the given service is exist.
+getBackend returns the Service's backend, falling back to the default
+client's backend for Services built without going through Unit().
 */
-func unitExist(name string) (bool, error) {
-
-	unitPaths := []string{
-		"/usr/lib/systemd/system/",
-		"/etc/systemd/system/",
-		"/usr/local/lib/systemd/system/",
-		"/etc/systemd/user/",
-		"/etc/systemd/system.control/",
-		"/run/systemd/system.control/",
-		"/run/systemd/transient/",
-		"/run/systemd/generator.early/",
-		"/etc/systemd/systemd.attached/",
-		"/run/systemd/system/",
-		"/run/systemd/systemd.attached/",
-		"/run/systemd/generator/",
-		"/lib/systemd/system/",
-		"/run/systemd/generator.late/",
-		"/usr/lib/systemd/user/"}
-
-	for _, unitPath := range unitPaths {
-
-		if _, err := os.Stat(unitPath); os.IsNotExist(err) {
-			continue
-		}
-
-		files, err := ioutil.ReadDir(unitPath)
-
-		if err != nil {
-			return false, err
-		}
-
-		for _, file := range files {
-			if file.Name() == name {
-				return true, nil
-			}
-		}
+func (s *Service) getBackend() Backend {
+
+	if s.backend != nil {
+		return s.backend
 	}
 
-	return false, nil
+	return defaultClient.backend()
 }
 
 /*
-Unit gives back Service.
-It checks whether a service with name exist.
-The struct saves the actual state when the function is called.
+getClient returns the Service's Client, falling back to defaultClient
+for Services built without going through Unit().
 */
-func Unit(name string) (Service, error) {
+func (s *Service) getClient() *Client {
 
-	if exist, err := unitExist(name); err != nil {
-		return Service{}, err
-	} else if exist != true {
-		return Service{}, fmt.Errorf("unit not exist: %s", name)
+	if s.client != nil {
+		return s.client
 	}
 
-	active, err := IsActive(name)
-
-	if err != nil {
-		return Service{}, err
-	}
-
-	enabled, err := IsEnabled(name)
-
-	if err != nil {
-		return Service{}, err
-	}
+	return defaultClient
+}
 
-	return Service{Name: name, Active: active, Enabled: enabled}, nil
+/*
+Unit gives back Service.
+It checks whether a service with name exist.
+The struct saves the actual state when the function is called.
+*/
+func Unit(name string) (Service, error) {
+	return defaultClient.Unit(name)
 }
 
 /*
@@ -115,23 +92,7 @@ IsActive checks if the given service is running.
 Returns true if the the given service is active, returns false otherwise.
 */
 func IsActive(name string) (bool, error) {
-
-	//if exist, err := unitExist(name); err
-
-	output, err := exec.Command("/usr/bin/systemctl", "is-active", name).CombinedOutput()
-
-	if err != nil {
-		return false, fmt.Errorf("failed to run systemctl: %s %s", output, err)
-	}
-
-	switch string(output) {
-	case "active\n":
-		return true, nil
-	case "inactive\n":
-		return false, nil
-	default:
-		return false, fmt.Errorf("invalid response: %s", string(output))
-	}
+	return defaultClient.IsActive(name)
 }
 
 /*
@@ -139,223 +100,97 @@ IsEnabled check if the given service is enabled in systemd.
 Returns true if the the given service is enabled.
 */
 func IsEnabled(name string) (bool, error) {
-
-	output, err := exec.Command("/usr/bin/systemctl", "is-enabled", name).CombinedOutput()
-
-	if err != nil {
-		return false, fmt.Errorf("failed to run systemctl: %s %s", output, err)
-	}
-
-	switch string(output) {
-	case "enabled\n":
-		return true, nil
-	case "disabled\n":
-		return false, nil
-	default:
-		return false, fmt.Errorf("invalid response: %s", string(output))
-	}
+	return defaultClient.IsEnabled(name)
 }
 
 /*
 Enable function enables the given service in systemd.
 */
 func (s *Service) Enable() error {
-
-	output, err := exec.Command("/usr/bin/systemctl", "enable", s.Name).CombinedOutput()
-
-	if err != nil {
-		return fmt.Errorf("%s %s", output, err)
-	}
-
-	return nil
+	return s.getBackend().Enable(s.Name)
 }
 
 /*
 Disable function disable the given service in systemd.
 */
 func (s *Service) Disable() error {
-
-	output, err := exec.Command("/usr/bin/systemctl", "disable", s.Name).CombinedOutput()
-
-	if err != nil {
-		return fmt.Errorf("%s %s", output, err)
-	}
-
-	return nil
+	return s.getBackend().Disable(s.Name)
 }
 
 /*
-Start function start the given service with systemctl.
+Start function starts the given service with systemctl, waiting for
+the systemd job to finish or for ctx to be done.
 */
-func (s *Service) Start() error {
-
-	output, err := exec.Command("/usr/bin/systemctl", "start", s.Name).CombinedOutput()
-
-	if err != nil {
-		return fmt.Errorf("%s %s", output, err)
-	}
-
-	return nil
+func (s *Service) Start(ctx context.Context) error {
+	return s.getBackend().Start(ctx, s.Name)
 }
 
 /*
-Stop function is stop the given service with systemctl.
+Stop function stops the given service with systemctl, waiting for the
+systemd job to finish or for ctx to be done.
 */
-func (s *Service) Stop() error {
-
-	output, err := exec.Command("/usr/bin/systemctl", "stop", s.Name).CombinedOutput()
-
-	if err != nil {
-		return fmt.Errorf("%s %s", output, err)
-	}
-
-	return nil
+func (s *Service) Stop(ctx context.Context) error {
+	return s.getBackend().Stop(ctx, s.Name)
 }
 
 /*
-Restart function restart the given service with systemctl.
+Restart function restarts the given service with systemctl, waiting
+for the systemd job to finish or for ctx to be done.
 */
-func (s *Service) Restart() error {
-
-	output, err := exec.Command("/usr/bin/systemctl", "restart", s.Name).CombinedOutput()
-
-	if err != nil {
-		return fmt.Errorf("%s %s", output, err)
-	}
-
-	return nil
+func (s *Service) Restart(ctx context.Context) error {
+	return s.getBackend().Restart(ctx, s.Name)
 }
 
 /*
-Reload function reload the given service with systemctl.
+Reload function reloads the given service with systemctl, waiting for
+the systemd job to finish or for ctx to be done.
 */
-func (s *Service) Reload() error {
-
-	output, err := exec.Command("/usr/bin/systemctl", "reload", s.Name).CombinedOutput()
-
-	if err != nil {
-		return fmt.Errorf("%s %s", output, err)
-	}
-
-	return nil
+func (s *Service) Reload(ctx context.Context) error {
+	return s.getBackend().Reload(ctx, s.Name)
 }
 
 /*
 EnableService function enables the given service in systemd.
 */
 func EnableService(name string) error {
-
-	if exist, err := unitExist(name); err != nil {
-		return err
-	} else if exist != true {
-		return fmt.Errorf("unit not exist: %s", name)
-	}
-
-	output, err := exec.Command("/usr/bin/systemctl", "enable", name).CombinedOutput()
-
-	if err != nil {
-		return fmt.Errorf("%s %s", output, err)
-	}
-
-	return nil
+	return defaultClient.EnableService(name)
 }
 
 /*
 DisableService function disable the given service in systemd.
 */
 func DisableService(name string) error {
-
-	if exist, err := unitExist(name); err != nil {
-		return err
-	} else if exist != true {
-		return fmt.Errorf("unit not exist: %s", name)
-	}
-
-	output, err := exec.Command("/usr/bin/systemctl", "disable", name).CombinedOutput()
-
-	if err != nil {
-		return fmt.Errorf("%s %s", output, err)
-	}
-
-	return nil
+	return defaultClient.DisableService(name)
 }
 
 /*
-StartService function start the given service with systemctl.
+StartService function starts the given service with systemctl, waiting
+for the systemd job to finish or for ctx to be done.
 */
-func StartService(name string) error {
-
-	if exist, err := unitExist(name); err != nil {
-		return err
-	} else if exist != true {
-		return fmt.Errorf("unit not exist: %s", name)
-	}
-
-	output, err := exec.Command("/usr/bin/systemctl", "start", name).CombinedOutput()
-
-	if err != nil {
-		return fmt.Errorf("%s %s", output, err)
-	}
-
-	return nil
+func StartService(ctx context.Context, name string) error {
+	return defaultClient.StartService(ctx, name)
 }
 
 /*
-StopService function is stop the given service with systemctl.
+StopService function stops the given service with systemctl, waiting
+for the systemd job to finish or for ctx to be done.
 */
-func StopService(name string) error {
-
-	if exist, err := unitExist(name); err != nil {
-		return err
-	} else if exist != true {
-		return fmt.Errorf("unit not exist: %s", name)
-	}
-
-	output, err := exec.Command("/usr/bin/systemctl", "stop", name).CombinedOutput()
-
-	if err != nil {
-		return fmt.Errorf("%s %s", output, err)
-	}
-
-	return nil
+func StopService(ctx context.Context, name string) error {
+	return defaultClient.StopService(ctx, name)
 }
 
 /*
-RestartService function restart the given service with systemctl.
+RestartService function restarts the given service with systemctl,
+waiting for the systemd job to finish or for ctx to be done.
 */
-func RestartService(name string) error {
-
-	if exist, err := unitExist(name); err != nil {
-		return err
-	} else if exist != true {
-		return fmt.Errorf("unit not exist: %s", name)
-	}
-
-	output, err := exec.Command("/usr/bin/systemctl", "restart", name).CombinedOutput()
-
-	if err != nil {
-		return fmt.Errorf("%s %s", output, err)
-	}
-
-	return nil
+func RestartService(ctx context.Context, name string) error {
+	return defaultClient.RestartService(ctx, name)
 }
 
 /*
-ReloadService function reload the given service with systemctl.
+ReloadService function reloads the given service with systemctl,
+waiting for the systemd job to finish or for ctx to be done.
 */
-func ReloadService(name string) error {
-
-	if exist, err := unitExist(name); err != nil {
-		return err
-	} else if exist != true {
-		return fmt.Errorf("unit not exist: %s", name)
-	}
-
-	output, err := exec.Command("/usr/bin/systemctl", "reload", name).CombinedOutput()
-
-	if err != nil {
-		return fmt.Errorf("%s %s", output, err)
-	}
-
-	return nil
+func ReloadService(ctx context.Context, name string) error {
+	return defaultClient.ReloadService(ctx, name)
 }
@@ -0,0 +1,308 @@
+package systemctl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+/*
+Scope selects whether a unit is managed by the system manager or by
+the invoking user's manager (`systemctl --user`).
+*/
+type Scope int
+
+const (
+	SystemScope Scope = iota
+	UserScope
+)
+
+/*
+unitDir returns the directory unit files for this scope are installed
+into. It shares its user-scope resolution with unitSearchPaths (via
+userUnitConfigDir) so InstallUnit/UninstallUnit and unitExist never
+disagree about where a user-scope unit file lives.
+*/
+func (s Scope) unitDir() (string, error) {
+
+	if s == SystemScope {
+		return "/etc/systemd/system/", nil
+	}
+
+	return userUnitConfigDir()
+}
+
+/*
+UnitSection holds the [Unit] directives of a unit file.
+*/
+type UnitSection struct {
+	Description   string
+	Documentation []string
+	After         []string
+	Before        []string
+	Requires      []string
+	Wants         []string
+	Conflicts     []string
+}
+
+/*
+ServiceSection holds the [Service] directives of a unit file.
+*/
+type ServiceSection struct {
+	Type             string
+	ExecStart        string
+	ExecStop         string
+	ExecReload       string
+	Restart          string
+	Environment      []string
+	WorkingDirectory string
+	User             string
+	Group            string
+}
+
+/*
+InstallSection holds the [Install] directives of a unit file.
+*/
+type InstallSection struct {
+	WantedBy   []string
+	RequiredBy []string
+	Alias      []string
+}
+
+/*
+UnitFile represents the content of a systemd unit file.
+*/
+type UnitFile struct {
+	Unit    UnitSection
+	Service ServiceSection
+	Install InstallSection
+}
+
+/*
+escapeExecArg quotes an ExecStart/ExecStop/ExecReload argument if it
+contains whitespace or a double quote, as systemd.service(5) requires.
+*/
+func escapeExecArg(arg string) string {
+
+	if !strings.ContainsAny(arg, " \t\"") {
+		return arg
+	}
+
+	return `"` + strings.ReplaceAll(arg, `"`, `\"`) + `"`
+}
+
+/*
+BuildExecLine joins path and args into a single Exec*-style exec line,
+escaping any argument that contains whitespace or a double quote.
+*/
+func BuildExecLine(path string, args ...string) string {
+
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, escapeExecArg(path))
+
+	for _, arg := range args {
+		parts = append(parts, escapeExecArg(arg))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+/*
+Marshal renders u as INI-style systemd unit syntax.
+*/
+func (u *UnitFile) Marshal() ([]byte, error) {
+
+	var b strings.Builder
+
+	b.WriteString("[Unit]\n")
+
+	if u.Unit.Description != "" {
+		fmt.Fprintf(&b, "Description=%s\n", u.Unit.Description)
+	}
+
+	for _, doc := range u.Unit.Documentation {
+		fmt.Fprintf(&b, "Documentation=%s\n", doc)
+	}
+
+	for _, after := range u.Unit.After {
+		fmt.Fprintf(&b, "After=%s\n", after)
+	}
+
+	for _, before := range u.Unit.Before {
+		fmt.Fprintf(&b, "Before=%s\n", before)
+	}
+
+	for _, requires := range u.Unit.Requires {
+		fmt.Fprintf(&b, "Requires=%s\n", requires)
+	}
+
+	for _, wants := range u.Unit.Wants {
+		fmt.Fprintf(&b, "Wants=%s\n", wants)
+	}
+
+	for _, conflicts := range u.Unit.Conflicts {
+		fmt.Fprintf(&b, "Conflicts=%s\n", conflicts)
+	}
+
+	b.WriteString("\n[Service]\n")
+
+	if u.Service.Type != "" {
+		fmt.Fprintf(&b, "Type=%s\n", u.Service.Type)
+	}
+
+	if u.Service.ExecStart != "" {
+		fmt.Fprintf(&b, "ExecStart=%s\n", u.Service.ExecStart)
+	}
+
+	if u.Service.ExecStop != "" {
+		fmt.Fprintf(&b, "ExecStop=%s\n", u.Service.ExecStop)
+	}
+
+	if u.Service.ExecReload != "" {
+		fmt.Fprintf(&b, "ExecReload=%s\n", u.Service.ExecReload)
+	}
+
+	if u.Service.Restart != "" {
+		fmt.Fprintf(&b, "Restart=%s\n", u.Service.Restart)
+	}
+
+	for _, env := range u.Service.Environment {
+		fmt.Fprintf(&b, "Environment=%s\n", env)
+	}
+
+	if u.Service.WorkingDirectory != "" {
+		fmt.Fprintf(&b, "WorkingDirectory=%s\n", u.Service.WorkingDirectory)
+	}
+
+	if u.Service.User != "" {
+		fmt.Fprintf(&b, "User=%s\n", u.Service.User)
+	}
+
+	if u.Service.Group != "" {
+		fmt.Fprintf(&b, "Group=%s\n", u.Service.Group)
+	}
+
+	b.WriteString("\n[Install]\n")
+
+	for _, wantedBy := range u.Install.WantedBy {
+		fmt.Fprintf(&b, "WantedBy=%s\n", wantedBy)
+	}
+
+	for _, requiredBy := range u.Install.RequiredBy {
+		fmt.Fprintf(&b, "RequiredBy=%s\n", requiredBy)
+	}
+
+	for _, alias := range u.Install.Alias {
+		fmt.Fprintf(&b, "Alias=%s\n", alias)
+	}
+
+	return []byte(b.String()), nil
+}
+
+/*
+InstallOptions controls how InstallUnit writes and activates a unit
+file.
+*/
+type InstallOptions struct {
+	Scope  Scope
+	Enable bool
+	Start  bool
+}
+
+/*
+daemonReload makes systemd re-read unit files from disk after they
+change.
+*/
+func daemonReload(scope Scope) error {
+
+	args := []string{"daemon-reload"}
+
+	if scope == UserScope {
+		args = append([]string{"--user"}, args...)
+	}
+
+	output, err := exec.Command("/usr/bin/systemctl", args...).CombinedOutput()
+
+	if err != nil {
+		return fmt.Errorf("failed to run systemctl: %s %s", output, err)
+	}
+
+	return nil
+}
+
+/*
+InstallUnit writes content as the unit file for name, reloads the
+systemd manager configuration, and optionally enables and starts the
+unit.
+*/
+func InstallUnit(name string, content []byte, opts InstallOptions) error {
+
+	dir, err := opts.Scope.unitDir()
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create unit directory: %s", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+		return fmt.Errorf("failed to write unit file: %s", err)
+	}
+
+	if err := daemonReload(opts.Scope); err != nil {
+		return err
+	}
+
+	client := NewClient(opts.Scope)
+	defer client.Close()
+
+	if opts.Enable {
+		if err := client.EnableService(name); err != nil {
+			return err
+		}
+	}
+
+	if opts.Start {
+		if err := client.StartService(context.Background(), name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+UninstallUnit disables and stops name in scope, removes its unit file,
+and reloads the systemd manager configuration. scope must match the
+one the unit was installed with (InstallOptions.Scope).
+*/
+func UninstallUnit(name string, scope Scope) error {
+
+	client := NewClient(scope)
+	defer client.Close()
+
+	if err := client.DisableService(name); err != nil {
+		return err
+	}
+
+	if err := client.StopService(context.Background(), name); err != nil {
+		return err
+	}
+
+	dir, err := scope.unitDir()
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(dir, name)); err != nil {
+		return fmt.Errorf("failed to remove unit file: %s", err)
+	}
+
+	return daemonReload(scope)
+}
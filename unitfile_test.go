@@ -0,0 +1,95 @@
+package systemctl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildExecLine(t *testing.T) {
+
+	cases := []struct {
+		name string
+		path string
+		args []string
+		want string
+	}{
+		{
+			name: "no args",
+			path: "/usr/bin/true",
+			want: "/usr/bin/true",
+		},
+		{
+			name: "plain args",
+			path: "/usr/bin/echo",
+			args: []string{"hello", "world"},
+			want: "/usr/bin/echo hello world",
+		},
+		{
+			name: "arg with space is quoted",
+			path: "/usr/bin/echo",
+			args: []string{"hello world"},
+			want: `/usr/bin/echo "hello world"`,
+		},
+		{
+			name: "arg with quote is escaped",
+			path: "/usr/bin/echo",
+			args: []string{`say "hi"`},
+			want: `/usr/bin/echo "say \"hi\""`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+
+			got := BuildExecLine(tc.path, tc.args...)
+
+			if got != tc.want {
+				t.Errorf("BuildExecLine(%q, %v) = %q, want %q", tc.path, tc.args, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnitFileMarshal(t *testing.T) {
+
+	u := &UnitFile{
+		Unit: UnitSection{
+			Description: "Example service",
+			After:       []string{"network.target"},
+		},
+		Service: ServiceSection{
+			Type:      "simple",
+			ExecStart: BuildExecLine("/usr/bin/example", "--flag", "value with space"),
+			Restart:   "on-failure",
+		},
+		Install: InstallSection{
+			WantedBy: []string{"multi-user.target"},
+		},
+	}
+
+	content, err := u.Marshal()
+
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %s", err)
+	}
+
+	got := string(content)
+
+	wantLines := []string{
+		"[Unit]",
+		"Description=Example service",
+		"After=network.target",
+		"[Service]",
+		"Type=simple",
+		`ExecStart=/usr/bin/example --flag "value with space"`,
+		"Restart=on-failure",
+		"[Install]",
+		"WantedBy=multi-user.target",
+	}
+
+	for _, line := range wantLines {
+		if !strings.Contains(got, line) {
+			t.Errorf("Marshal() output missing line %q, got:\n%s", line, got)
+		}
+	}
+}
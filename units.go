@@ -0,0 +1,139 @@
+package systemctl
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+/*
+Filter narrows down the units ListUnits returns.
+*/
+type Filter struct {
+	Type    string // unit suffix without the dot, e.g. "service", "socket", "timer"
+	State   string // ActiveState or SubState to require, e.g. "failed", "running"
+	Pattern string // glob matched against the unit name
+}
+
+/*
+match reports whether name/activeState/subState satisfy f. State is
+checked against both ActiveState (e.g. "active", "failed") and
+SubState (e.g. "running", "dead", "exited"), since both are commonly
+used to filter `systemctl list-units` output and a caller shouldn't
+need to know which column a given value lives in. A zero-value Filter
+matches everything.
+*/
+func (f Filter) match(name, activeState, subState string) bool {
+
+	if f.Type != "" && !strings.HasSuffix(name, "."+f.Type) {
+		return false
+	}
+
+	if f.State != "" && f.State != activeState && f.State != subState {
+		return false
+	}
+
+	if f.Pattern != "" {
+		if ok, err := filepath.Match(f.Pattern, name); err != nil || !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+ListUnits runs `systemctl list-units --all --plain --no-legend
+--no-pager` for c's scope/host and returns the units matching filter.
+*/
+func (c *Client) ListUnits(filter Filter) ([]Service, error) {
+
+	output, err := exec.Command("/usr/bin/systemctl", c.args("list-units", "--all", "--plain", "--no-legend", "--no-pager")...).CombinedOutput()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to run systemctl: %s %s", output, err)
+	}
+
+	var services []Service
+
+	for _, line := range strings.Split(string(output), "\n") {
+
+		fields := strings.Fields(line)
+
+		if len(fields) < 4 {
+			continue
+		}
+
+		name, load, active, sub := fields[0], fields[1], fields[2], fields[3]
+
+		if !filter.match(name, active, sub) {
+			continue
+		}
+
+		services = append(services, Service{
+			Name:        name,
+			LoadState:   load,
+			ActiveState: active,
+			SubState:    sub,
+			Description: strings.Join(fields[4:], " "),
+			Active:      active == "active",
+			client:      c,
+			backend:     c.backend(),
+		})
+	}
+
+	return services, nil
+}
+
+/*
+ListUnits runs ListUnits against the default system-scope client.
+*/
+func ListUnits(filter Filter) ([]Service, error) {
+	return defaultClient.ListUnits(filter)
+}
+
+/*
+UnitFileEntry is one row of `systemctl list-unit-files`: a unit file
+and its install state (enabled, disabled, static, masked, ...).
+*/
+type UnitFileEntry struct {
+	Name  string
+	State string
+}
+
+/*
+ListUnitFiles runs `systemctl list-unit-files --no-legend --no-pager`
+for c's scope/host and returns every installed unit file.
+*/
+func (c *Client) ListUnitFiles() ([]UnitFileEntry, error) {
+
+	output, err := exec.Command("/usr/bin/systemctl", c.args("list-unit-files", "--no-legend", "--no-pager")...).CombinedOutput()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to run systemctl: %s %s", output, err)
+	}
+
+	var entries []UnitFileEntry
+
+	for _, line := range strings.Split(string(output), "\n") {
+
+		fields := strings.Fields(line)
+
+		if len(fields) < 2 {
+			continue
+		}
+
+		entries = append(entries, UnitFileEntry{Name: fields[0], State: fields[1]})
+	}
+
+	return entries, nil
+}
+
+/*
+ListUnitFiles runs ListUnitFiles against the default system-scope
+client.
+*/
+func ListUnitFiles() ([]UnitFileEntry, error) {
+	return defaultClient.ListUnitFiles()
+}
@@ -0,0 +1,91 @@
+package systemctl
+
+import "testing"
+
+func TestFilterMatch(t *testing.T) {
+
+	cases := []struct {
+		name        string
+		filter      Filter
+		unit        string
+		activeState string
+		subState    string
+		want        bool
+	}{
+		{
+			name:        "empty filter matches everything",
+			filter:      Filter{},
+			unit:        "sshd.service",
+			activeState: "active",
+			subState:    "running",
+			want:        true,
+		},
+		{
+			name:        "type mismatch",
+			filter:      Filter{Type: "socket"},
+			unit:        "sshd.service",
+			activeState: "active",
+			subState:    "running",
+			want:        false,
+		},
+		{
+			name:        "type match",
+			filter:      Filter{Type: "service"},
+			unit:        "sshd.service",
+			activeState: "active",
+			subState:    "running",
+			want:        true,
+		},
+		{
+			name:        "state matches ActiveState",
+			filter:      Filter{State: "failed"},
+			unit:        "sshd.service",
+			activeState: "failed",
+			subState:    "failed",
+			want:        true,
+		},
+		{
+			name:        "state matches SubState",
+			filter:      Filter{State: "running"},
+			unit:        "sshd.service",
+			activeState: "active",
+			subState:    "running",
+			want:        true,
+		},
+		{
+			name:        "state matches neither",
+			filter:      Filter{State: "running"},
+			unit:        "sshd.service",
+			activeState: "inactive",
+			subState:    "dead",
+			want:        false,
+		},
+		{
+			name:        "pattern match",
+			filter:      Filter{Pattern: "ssh*"},
+			unit:        "sshd.service",
+			activeState: "active",
+			subState:    "running",
+			want:        true,
+		},
+		{
+			name:        "pattern mismatch",
+			filter:      Filter{Pattern: "nginx*"},
+			unit:        "sshd.service",
+			activeState: "active",
+			subState:    "running",
+			want:        false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+
+			got := tc.filter.match(tc.unit, tc.activeState, tc.subState)
+
+			if got != tc.want {
+				t.Errorf("match(%q, %q, %q) = %v, want %v", tc.unit, tc.activeState, tc.subState, got, tc.want)
+			}
+		})
+	}
+}